@@ -9,9 +9,12 @@ import (
 // Client is a durable streams client.
 // It is safe for concurrent use.
 type Client struct {
-	httpClient  *http.Client
-	baseURL     string
-	retryPolicy RetryPolicy
+	httpClient     *http.Client
+	baseURL        string
+	retryPolicy    RetryPolicy
+	eventHandler   EventHandler
+	acceptEncoding string
+	autoDecompress bool
 }
 
 // NewClient creates a new durable streams client.
@@ -37,6 +40,13 @@ func NewClient(opts ...ClientOption) *Client {
 				IdleConnTimeout:     90 * time.Second,
 			},
 		}
+		if cfg.http2Options != nil {
+			// Ignore the error: ConfigureTransports only fails if the
+			// transport was already mutated into a state it can't
+			// recognize, which can't happen for the default transport
+			// built above.
+			_ = configureHTTP2(httpClient, *cfg.http2Options)
+		}
 	}
 
 	// Default retry policy
@@ -45,10 +55,23 @@ func NewClient(opts ...ClientOption) *Client {
 		retryPolicy = *cfg.retryPolicy
 	}
 
+	acceptEncoding := defaultAcceptEncoding
+	if cfg.acceptEncoding != "" {
+		acceptEncoding = cfg.acceptEncoding
+	}
+
+	autoDecompress := true
+	if cfg.autoDecompress != nil {
+		autoDecompress = *cfg.autoDecompress
+	}
+
 	return &Client{
-		httpClient:  httpClient,
-		baseURL:     strings.TrimSuffix(cfg.baseURL, "/"),
-		retryPolicy: retryPolicy,
+		httpClient:     httpClient,
+		baseURL:        strings.TrimSuffix(cfg.baseURL, "/"),
+		retryPolicy:    retryPolicy,
+		eventHandler:   cfg.eventHandler,
+		acceptEncoding: acceptEncoding,
+		autoDecompress: autoDecompress,
 	}
 }
 
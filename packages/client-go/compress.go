@@ -0,0 +1,43 @@
+package durablestreams
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// defaultAcceptEncoding is sent on every read request unless overridden
+// with WithAcceptEncoding, so CDNs in front of a stream are free to
+// serve a compressed representation.
+const defaultAcceptEncoding = "gzip, zstd"
+
+// decodeBody transparently decompresses body according to
+// contentEncoding, the same set of codecs net/http and the x/net
+// HTTP/2 transport understand for response bodies. An empty
+// contentEncoding (or one durable-streams doesn't recognize) returns
+// body unchanged.
+func decodeBody(contentEncoding string, body []byte) ([]byte, error) {
+	switch contentEncoding {
+	case "":
+		return body, nil
+	case "gzip":
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("durablestreams: gzip decode: %w", err)
+		}
+		defer zr.Close()
+		return io.ReadAll(zr)
+	case "zstd":
+		zr, err := zstd.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("durablestreams: zstd decode: %w", err)
+		}
+		defer zr.Close()
+		return io.ReadAll(zr)
+	default:
+		return body, nil
+	}
+}
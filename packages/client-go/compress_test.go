@@ -0,0 +1,61 @@
+package durablestreams
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestDecodeBodyPassthrough(t *testing.T) {
+	got, err := decodeBody("", []byte("hello"))
+	if err != nil {
+		t.Fatalf("decodeBody: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Fatalf("got %q, want %q", got, "hello")
+	}
+}
+
+func TestDecodeBodyGzip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte("hello gzip")); err != nil {
+		t.Fatalf("gzip write: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+
+	got, err := decodeBody("gzip", buf.Bytes())
+	if err != nil {
+		t.Fatalf("decodeBody: %v", err)
+	}
+	if string(got) != "hello gzip" {
+		t.Fatalf("got %q, want %q", got, "hello gzip")
+	}
+}
+
+func TestDecodeBodyZstd(t *testing.T) {
+	zw, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatalf("zstd.NewWriter: %v", err)
+	}
+	compressed := zw.EncodeAll([]byte("hello zstd"), nil)
+	zw.Close()
+
+	got, err := decodeBody("zstd", compressed)
+	if err != nil {
+		t.Fatalf("decodeBody: %v", err)
+	}
+	if string(got) != "hello zstd" {
+		t.Fatalf("got %q, want %q", got, "hello zstd")
+	}
+}
+
+func TestDecodeBodyInvalidGzipReturnsError(t *testing.T) {
+	if _, err := decodeBody("gzip", []byte("not gzip")); err == nil {
+		t.Fatal("expected error decoding invalid gzip body")
+	}
+}
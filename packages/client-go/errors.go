@@ -0,0 +1,51 @@
+package durablestreams
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Done is returned by ChunkIterator.Next when iteration has completed
+// (non-live mode, caught up to stream head).
+var Done = errors.New("durablestreams: done")
+
+// ErrAlreadyClosed is returned when an operation is attempted on a
+// ChunkIterator after Close has been called.
+var ErrAlreadyClosed = errors.New("durablestreams: iterator already closed")
+
+// ErrStreamNotFound is returned when the stream does not exist (404).
+var ErrStreamNotFound = errors.New("durablestreams: stream not found")
+
+// ErrOffsetGone is returned when the requested offset has fallen out of
+// the stream's retention window (410).
+var ErrOffsetGone = errors.New("durablestreams: offset gone")
+
+// StreamError wraps an error encountered while performing an operation
+// against a stream.
+type StreamError struct {
+	Op         string
+	URL        string
+	StatusCode int
+	Err        error
+}
+
+func (e *StreamError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("durablestreams: %s %s: %s (status %d)", e.Op, e.URL, e.Err, e.StatusCode)
+	}
+	return fmt.Sprintf("durablestreams: %s %s: %s", e.Op, e.URL, e.Err)
+}
+
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+func newStreamError(op, url string, statusCode int, err error) *StreamError {
+	return &StreamError{Op: op, URL: url, StatusCode: statusCode, Err: err}
+}
+
+// errorFromStatus maps an unexpected HTTP status code to an error.
+func errorFromStatus(statusCode int) error {
+	return fmt.Errorf("unexpected status %d %s", statusCode, http.StatusText(statusCode))
+}
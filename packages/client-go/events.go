@@ -0,0 +1,96 @@
+package durablestreams
+
+import (
+	"context"
+	"net/http/httptrace"
+	"time"
+)
+
+// EventHandler receives observability callbacks from ChunkIterator.Next
+// around each HTTP request, so operators running durable-streams behind
+// a CDN can tell whether a chunk came from cache, how long the origin
+// took, and when a long-poll was parked waiting.
+//
+// Implementations must be safe for concurrent use: a single Client's
+// ChunkIterators may invoke these callbacks from multiple goroutines.
+type EventHandler interface {
+	// OnRequest is called before each read request is sent.
+	OnRequest(url string, offset Offset, cursor string)
+
+	// OnResponse is called after a 200 response has been fully read.
+	// bytes is len(Chunk.Data); dur covers the full round trip,
+	// including reading the body.
+	OnResponse(statusCode int, etag string, upToDate bool, bytes int, dur time.Duration)
+
+	// OnRetry is called when a read attempt failed and is about to be
+	// retried per the Client's RetryPolicy.
+	OnRetry(attempt int, err error)
+
+	// On304 is called on a Not Modified cache hit.
+	On304()
+
+	// On204 is called on a No Content long-poll timeout.
+	On204()
+
+	// OnGone is called when the requested offset has fallen out of the
+	// stream's retention window (410).
+	OnGone()
+}
+
+// TracingEventHandler is an optional extension of EventHandler for
+// callers who also want low-level connection timings (DNS, connect,
+// TLS, GotConn, WroteRequest, GotFirstResponseByte) for each request.
+// If the EventHandler passed to WithEventHandler implements this
+// interface, ChunkIterator.Next attaches the returned trace to the
+// request context the same way the x/net HTTP/2 transport instruments
+// its own requests.
+type TracingEventHandler interface {
+	EventHandler
+	ClientTrace() *httptrace.ClientTrace
+}
+
+// withEventTrace attaches handler's httptrace.ClientTrace to ctx, if
+// handler implements TracingEventHandler.
+func withEventTrace(ctx context.Context, handler EventHandler) context.Context {
+	te, ok := handler.(TracingEventHandler)
+	if !ok {
+		return ctx
+	}
+	trace := te.ClientTrace()
+	if trace == nil {
+		return ctx
+	}
+	return httptrace.WithClientTrace(ctx, trace)
+}
+
+// WithEventHandler registers an EventHandler invoked by every
+// ChunkIterator created from this Client.
+//
+// Example (Prometheus-friendly handler; adapt the metric calls to
+// whichever client library and label set your service uses):
+//
+//	type promEvents struct {
+//	    requests  *prometheus.CounterVec   // labels: status
+//	    duration  *prometheus.HistogramVec // labels: status
+//	    retries   prometheus.Counter
+//	}
+//
+//	func (p *promEvents) OnRequest(url string, offset durablestreams.Offset, cursor string) {}
+//
+//	func (p *promEvents) OnResponse(status int, etag string, upToDate bool, bytes int, dur time.Duration) {
+//	    label := strconv.Itoa(status)
+//	    p.requests.WithLabelValues(label).Inc()
+//	    p.duration.WithLabelValues(label).Observe(dur.Seconds())
+//	}
+//
+//	func (p *promEvents) OnRetry(attempt int, err error) { p.retries.Inc() }
+//	func (p *promEvents) On304()                         {}
+//	func (p *promEvents) On204()                         {}
+//	func (p *promEvents) OnGone()                        {}
+//
+//	client := durablestreams.NewClient(durablestreams.WithEventHandler(&promEvents{...}))
+func WithEventHandler(handler EventHandler) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.eventHandler = handler
+	}
+}
@@ -0,0 +1,249 @@
+package durablestreams
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingEvents is an EventHandler that records every callback it
+// receives, for assertions. It does not implement TracingEventHandler;
+// tracingEvents below wraps it to add that.
+type recordingEvents struct {
+	mu sync.Mutex
+
+	requestURLs    []string
+	responseStatus []int
+	responseBytes  []int
+	retryAttempts  []int
+	retryErrs      []error
+	count304       int
+	count204       int
+	countGone      int
+}
+
+func (e *recordingEvents) OnRequest(url string, offset Offset, cursor string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.requestURLs = append(e.requestURLs, url)
+}
+
+func (e *recordingEvents) OnResponse(statusCode int, etag string, upToDate bool, bytes int, dur time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.responseStatus = append(e.responseStatus, statusCode)
+	e.responseBytes = append(e.responseBytes, bytes)
+}
+
+func (e *recordingEvents) OnRetry(attempt int, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.retryAttempts = append(e.retryAttempts, attempt)
+	e.retryErrs = append(e.retryErrs, err)
+}
+
+func (e *recordingEvents) On304() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.count304++
+}
+
+func (e *recordingEvents) On204() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.count204++
+}
+
+func (e *recordingEvents) OnGone() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.countGone++
+}
+
+// tracingEvents additionally implements TracingEventHandler, so
+// ChunkIterator.Next should attach its ClientTrace to the request
+// context.
+type tracingEvents struct {
+	*recordingEvents
+	gotConnCalled bool
+}
+
+func (e *tracingEvents) ClientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GotConn: func(httptrace.GotConnInfo) {
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			e.gotConnCalled = true
+		},
+	}
+}
+
+func newTracingEvents() *tracingEvents {
+	return &tracingEvents{recordingEvents: &recordingEvents{}}
+}
+
+func TestEventHandlerOnRequestAndOnResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerStreamOffset, "o1")
+		w.Header().Set(headerStreamUpToDate, "true")
+		w.Header().Set(headerETag, "etag-1")
+		w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+
+	events := &recordingEvents{}
+	client := NewClient(WithEventHandler(events))
+	stream := client.Stream(srv.URL)
+	it := stream.NewIterator(context.Background(), "", LiveModeNone)
+	defer it.Close()
+
+	chunk, err := it.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if string(chunk.Data) != "hello" {
+		t.Fatalf("got %q, want %q", chunk.Data, "hello")
+	}
+
+	if len(events.requestURLs) != 1 {
+		t.Fatalf("OnRequest called %d times, want 1", len(events.requestURLs))
+	}
+	if len(events.responseStatus) != 1 || events.responseStatus[0] != http.StatusOK {
+		t.Fatalf("OnResponse statuses = %v, want [200]", events.responseStatus)
+	}
+	if events.responseBytes[0] != len("hello") {
+		t.Fatalf("OnResponse bytes = %d, want %d", events.responseBytes[0], len("hello"))
+	}
+}
+
+func TestEventHandlerOn304(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerStreamCursor, "c1")
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer srv.Close()
+
+	events := &recordingEvents{}
+	client := NewClient(WithEventHandler(events))
+	stream := client.Stream(srv.URL)
+	it := stream.NewIterator(context.Background(), "", LiveModeLongPoll)
+	defer it.Close()
+
+	if _, err := it.Next(); err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if events.count304 != 1 {
+		t.Fatalf("On304 called %d times, want 1", events.count304)
+	}
+}
+
+func TestEventHandlerOn204(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerStreamUpToDate, "true")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	events := &recordingEvents{}
+	client := NewClient(WithEventHandler(events))
+	stream := client.Stream(srv.URL)
+	it := stream.NewIterator(context.Background(), "", LiveModeNone)
+	defer it.Close()
+
+	if _, err := it.Next(); err != Done {
+		t.Fatalf("Next err = %v, want Done", err)
+	}
+	if events.count204 != 1 {
+		t.Fatalf("On204 called %d times, want 1", events.count204)
+	}
+}
+
+func TestEventHandlerOnGone(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer srv.Close()
+
+	events := &recordingEvents{}
+	client := NewClient(WithEventHandler(events))
+	stream := client.Stream(srv.URL)
+	it := stream.NewIterator(context.Background(), "", LiveModeNone)
+	defer it.Close()
+
+	if _, err := it.Next(); err == nil {
+		t.Fatal("expected an error for 410 Gone")
+	}
+	if events.countGone != 1 {
+		t.Fatalf("OnGone called %d times, want 1", events.countGone)
+	}
+}
+
+func TestEventHandlerOnRetry(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Content-Length", "100")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("short"))
+			hj := w.(http.Hijacker)
+			conn, _, err := hj.Hijack()
+			if err != nil {
+				t.Fatalf("hijack: %v", err)
+			}
+			conn.Close()
+			return
+		}
+		w.Header().Set(headerStreamOffset, "o")
+		w.Header().Set(headerStreamUpToDate, "true")
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	events := &recordingEvents{}
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = 0
+	client := NewClient(WithRetryPolicy(policy), WithEventHandler(events))
+	stream := client.Stream(srv.URL)
+	it := stream.NewIterator(context.Background(), "", LiveModeNone)
+	defer it.Close()
+
+	if _, err := it.Next(); err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+
+	if len(events.retryAttempts) != 1 || events.retryAttempts[0] != 1 {
+		t.Fatalf("retryAttempts = %v, want [1]", events.retryAttempts)
+	}
+	if events.retryErrs[0] == nil {
+		t.Fatal("OnRetry err = nil, want non-nil")
+	}
+}
+
+func TestTracingEventHandlerAttachesClientTrace(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerStreamUpToDate, "true")
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	events := newTracingEvents()
+	client := NewClient(WithEventHandler(events))
+	stream := client.Stream(srv.URL)
+	it := stream.NewIterator(context.Background(), "", LiveModeNone)
+	defer it.Close()
+
+	if _, err := it.Next(); err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+
+	events.mu.Lock()
+	defer events.mu.Unlock()
+	if !events.gotConnCalled {
+		t.Fatal("httptrace.ClientTrace.GotConn was never called; trace not attached")
+	}
+}
@@ -0,0 +1,9 @@
+package durablestreams
+
+// HTTP headers used by the durable streams protocol.
+const (
+	headerStreamOffset   = "Stream-Offset"
+	headerStreamCursor   = "Stream-Cursor"
+	headerStreamUpToDate = "Stream-Up-To-Date"
+	headerETag           = "ETag"
+)
@@ -0,0 +1,121 @@
+package durablestreams
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// HTTP2Options configures the http2.Transport installed by WithHTTP2.
+// Fields mirror the corresponding knobs on x/net/http2.Transport;
+// see that package for full semantics.
+type HTTP2Options struct {
+	// StrictMaxConcurrentStreams, when true, makes the client respect
+	// the server's SETTINGS_MAX_CONCURRENT_STREAMS by blocking (or
+	// opening a second TCP connection) instead of racing extra streams
+	// onto a connection that has hit its cap. Recommended when many
+	// ChunkIterators fan out against one host via Client.Stream.
+	StrictMaxConcurrentStreams bool
+
+	// MaxHeaderListSize caps the size of the header list the client is
+	// willing to send/receive, in bytes. Zero means use the transport
+	// default.
+	MaxHeaderListSize uint32
+
+	// ReadIdleTimeout is how long to wait before sending a health check
+	// PING frame on an idle connection. This is what lets the client
+	// detect a long-poll connection that died silently behind a NAT or
+	// CDN without a TCP RST ever arriving. Zero disables health checks.
+	ReadIdleTimeout time.Duration
+
+	// PingTimeout is how long to wait for a PING response before the
+	// connection is considered dead and closed.
+	PingTimeout time.Duration
+
+	// AllowHTTP permits using http2 over a plaintext "http://" URL
+	// (h2c), for talking to an origin or CDN edge that terminates
+	// HTTP/2 without TLS.
+	AllowHTTP bool
+}
+
+// WithHTTP2 configures the Client to speak HTTP/2 explicitly via
+// golang.org/x/net/http2, rather than relying on the net/http default
+// (which only upgrades to HTTP/2 over TLS with no way to tune the
+// transport). This matters for a long-poll streaming client: HTTP/2
+// gives connection reuse across many concurrent streams, header
+// compression, and a real signal for the server's
+// SETTINGS_MAX_CONCURRENT_STREAMS.
+//
+// WithHTTP2 is mutually exclusive with WithHTTPClient; if both are
+// given, WithHTTPClient wins and WithHTTP2 is ignored, since the caller
+// is assumed to have already configured their own transport.
+func WithHTTP2(opts HTTP2Options) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.http2Options = &opts
+	}
+}
+
+// configureHTTP2 builds the *http.Transport / *http2.Transport pair
+// described by opts and installs it as httpClient's transport.
+func configureHTTP2(httpClient *http.Client, opts HTTP2Options) error {
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok || transport == nil {
+		transport = &http.Transport{
+			MaxIdleConns: 100,
+			// Raised from the net/http default of 2: a single Client
+			// typically fans Stream() out across many ChunkIterators
+			// targeting the same host.
+			MaxIdleConnsPerHost: 50,
+			IdleConnTimeout:     90 * time.Second,
+		}
+	}
+	transport.MaxIdleConnsPerHost = 50
+
+	h2Transport, err := http2.ConfigureTransports(transport)
+	if err != nil {
+		return err
+	}
+	h2Transport.StrictMaxConcurrentStreams = opts.StrictMaxConcurrentStreams
+	h2Transport.MaxHeaderListSize = opts.MaxHeaderListSize
+	h2Transport.ReadIdleTimeout = opts.ReadIdleTimeout
+	h2Transport.PingTimeout = opts.PingTimeout
+
+	if opts.AllowHTTP {
+		// http2.ConfigureTransports only wires h2Transport in for the
+		// "https" scheme (via TLSNextProto ALPN negotiation) — it never
+		// touches how "http://" requests are dialed. To actually get
+		// h2c we need a second, separately-configured *http2.Transport
+		// that dials plaintext TCP instead of TLS, registered to handle
+		// the "http" scheme directly on the outer transport.
+		h2cTransport := &http2.Transport{
+			AllowHTTP:                  true,
+			StrictMaxConcurrentStreams: opts.StrictMaxConcurrentStreams,
+			MaxHeaderListSize:          opts.MaxHeaderListSize,
+			ReadIdleTimeout:            opts.ReadIdleTimeout,
+			PingTimeout:                opts.PingTimeout,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		}
+		transport.RegisterProtocol("http", &h2cRoundTripper{h2cTransport})
+	}
+
+	httpClient.Transport = transport
+	return nil
+}
+
+// h2cRoundTripper adapts an AllowHTTP-configured *http2.Transport to
+// http.RoundTripper so it can be installed via
+// (*http.Transport).RegisterProtocol for the "http" scheme.
+type h2cRoundTripper struct {
+	transport *http2.Transport
+}
+
+func (rt *h2cRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return rt.transport.RoundTrip(req)
+}
@@ -0,0 +1,50 @@
+package durablestreams
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestWithHTTP2AllowHTTPUsesH2C(t *testing.T) {
+	h2s := &http2.Server{}
+	handler := h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), h2s)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	client := NewClient(WithHTTP2(HTTP2Options{AllowHTTP: true}))
+
+	resp, err := client.HTTPClient().Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.ProtoMajor != 2 {
+		t.Fatalf("got proto %q, want HTTP/2 (h2c)", resp.Proto)
+	}
+}
+
+func TestConfigureHTTP2WithoutAllowHTTPLeavesPlainHTTPAlone(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithHTTP2(HTTP2Options{StrictMaxConcurrentStreams: true}))
+
+	resp, err := client.HTTPClient().Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.ProtoMajor != 1 {
+		t.Fatalf("got proto %q, want HTTP/1.1 (no AllowHTTP, no h2c server)", resp.Proto)
+	}
+}
@@ -2,6 +2,7 @@ package durablestreams
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"sync"
@@ -14,9 +15,15 @@ type Chunk struct {
 	// Use this for resumption/checkpointing.
 	NextOffset Offset
 
-	// Data is the raw bytes from this response.
+	// Data is the (decompressed, unless WithAutoDecompress(false) was
+	// set) bytes from this response.
 	Data []byte
 
+	// CompressedSize is len(Data) as it crossed the wire, before any
+	// Content-Encoding decoding. It equals len(Data) when the response
+	// wasn't compressed or WithAutoDecompress(false) is in effect.
+	CompressedSize int
+
 	// UpToDate is true if this chunk ends at stream head.
 	UpToDate bool
 
@@ -68,6 +75,13 @@ type ChunkIterator struct {
 // Returns Done when iteration is complete (live=false and caught up).
 // In live mode, blocks waiting for new data.
 //
+// Transient failures (connection errors, 5xx, 429/503) are retried
+// transparently according to Client.retryPolicy, replaying the same
+// offset/cursor so retries hit the CDN cache. 408, 425 and 429 are
+// retried; 404/410 and other 4xx errors are never retried. Next returns
+// promptly if ctx is cancelled,
+// including while sleeping between attempts.
+//
 // Example:
 //
 //	for {
@@ -92,48 +106,117 @@ func (it *ChunkIterator) Next() (*Chunk, error) {
 	}
 	it.mu.Unlock()
 
+	policy := it.stream.client.retryPolicy
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		chunk, statusCode, retryAfter, err := it.next()
+		if err == nil || errors.Is(err, Done) {
+			return chunk, err
+		}
+		if it.ctx.Err() != nil {
+			return nil, it.ctx.Err()
+		}
+		if attempt >= maxAttempts || !policy.shouldRetry(err, statusCode) {
+			return nil, err
+		}
+
+		sleep := policy.backoff(attempt, retryAfter)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, statusCode, sleep)
+		}
+		if events := it.stream.client.eventHandler; events != nil {
+			events.OnRetry(attempt, err)
+		}
+
+		timer := time.NewTimer(sleep)
+		select {
+		case <-it.ctx.Done():
+			timer.Stop()
+			return nil, it.ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// next performs a single, non-retrying read attempt. statusCode is 0 for
+// errors that never reached the origin (connection errors); retryAfter
+// reflects a Retry-After response header, when present.
+func (it *ChunkIterator) next() (chunk *Chunk, statusCode int, retryAfter time.Duration, err error) {
 	// Check context
 	select {
 	case <-it.ctx.Done():
-		return nil, it.ctx.Err()
+		return nil, 0, 0, it.ctx.Err()
 	default:
 	}
 
 	// Build the read URL
 	readURL := it.stream.buildReadURL(it.offset, it.live, it.cursor)
 
+	events := it.stream.client.eventHandler
+	if events != nil {
+		events.OnRequest(readURL, it.offset, it.cursor)
+	}
+
+	reqCtx := it.ctx
+	if events != nil {
+		reqCtx = withEventTrace(reqCtx, events)
+	}
+
 	// Create request
-	req, err := http.NewRequestWithContext(it.ctx, http.MethodGet, readURL, nil)
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, readURL, nil)
 	if err != nil {
-		return nil, newStreamError("read", it.stream.url, 0, err)
+		return nil, 0, 0, newStreamError("read", it.stream.url, 0, err)
 	}
 
+	req.Header.Set("Accept-Encoding", it.stream.client.acceptEncoding)
+
 	// Set custom headers
 	for k, v := range it.headers {
 		req.Header.Set(k, v)
 	}
 
 	// Execute request
+	start := time.Now()
 	resp, err := it.stream.client.httpClient.Do(req)
 	if err != nil {
 		// Check if context was cancelled
 		if it.ctx.Err() != nil {
-			return nil, it.ctx.Err()
+			return nil, 0, 0, it.ctx.Err()
 		}
-		return nil, newStreamError("read", it.stream.url, 0, err)
+		return nil, 0, 0, newStreamError("read", it.stream.url, 0, err)
 	}
 	defer resp.Body.Close()
+	retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
 
 	// Handle response status
 	switch resp.StatusCode {
 	case http.StatusOK:
-		// Read body
-		data, err := io.ReadAll(resp.Body)
+		// Read body. A failure here (connection reset, EOF, bad gzip
+		// framing, ...) happened mid-transfer, not as an HTTP-level
+		// response status, so report statusCode 0 to let shouldRetry
+		// treat it like any other transport error instead of a
+		// permanent 200-status failure.
+		raw, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, newStreamError("read", it.stream.url, resp.StatusCode, err)
+			return nil, 0, retryAfter, newStreamError("read", it.stream.url, resp.StatusCode, err)
+		}
+		compressedSize := len(raw)
+
+		data := raw
+		if it.stream.client.autoDecompress {
+			data, err = decodeBody(resp.Header.Get("Content-Encoding"), raw)
+			if err != nil {
+				return nil, 0, retryAfter, newStreamError("read", it.stream.url, resp.StatusCode, err)
+			}
 		}
 
-		// Extract headers
+		// Extract headers. The ETag and cursor/offset headers identify
+		// the logical chunk and must be preserved verbatim, independent
+		// of whatever Content-Encoding suffix (if any) the CDN applied.
 		nextOffset := Offset(resp.Header.Get(headerStreamOffset))
 		cursor := resp.Header.Get(headerStreamCursor)
 		upToDate := resp.Header.Get(headerStreamUpToDate) == "true"
@@ -153,13 +236,18 @@ func (it *ChunkIterator) Next() (*Chunk, error) {
 		}
 		it.mu.Unlock()
 
+		if events != nil {
+			events.OnResponse(resp.StatusCode, etag, upToDate, len(data), time.Since(start))
+		}
+
 		return &Chunk{
-			NextOffset: nextOffset,
-			Data:       data,
-			UpToDate:   upToDate,
-			Cursor:     cursor,
-			ETag:       etag,
-		}, nil
+			NextOffset:     nextOffset,
+			Data:           data,
+			CompressedSize: compressedSize,
+			UpToDate:       upToDate,
+			Cursor:         cursor,
+			ETag:           etag,
+		}, resp.StatusCode, retryAfter, nil
 
 	case http.StatusNoContent:
 		// 204 - Long-poll timeout or caught up with no new data
@@ -182,17 +270,24 @@ func (it *ChunkIterator) Next() (*Chunk, error) {
 		if it.live == LiveModeNone {
 			it.doneOnce = true
 			it.mu.Unlock()
-			return nil, Done
+			if events != nil {
+				events.On204()
+			}
+			return nil, resp.StatusCode, retryAfter, Done
 		}
 		it.mu.Unlock()
 
+		if events != nil {
+			events.On204()
+		}
+
 		// In live mode, return empty chunk and continue
 		return &Chunk{
 			NextOffset: nextOffset,
 			Data:       nil,
 			UpToDate:   upToDate,
 			Cursor:     cursor,
-		}, nil
+		}, resp.StatusCode, retryAfter, nil
 
 	case http.StatusNotModified:
 		// 304 - Not modified (cache hit)
@@ -203,25 +298,31 @@ func (it *ChunkIterator) Next() (*Chunk, error) {
 			it.Cursor = cursor
 			it.mu.Unlock()
 		}
+		if events != nil {
+			events.On304()
+		}
 		// Return empty chunk
 		return &Chunk{
 			NextOffset: it.offset,
 			Data:       nil,
 			UpToDate:   it.UpToDate,
 			Cursor:     it.cursor,
-		}, nil
+		}, resp.StatusCode, retryAfter, nil
 
 	case http.StatusNotFound:
 		io.Copy(io.Discard, resp.Body)
-		return nil, newStreamError("read", it.stream.url, resp.StatusCode, ErrStreamNotFound)
+		return nil, resp.StatusCode, retryAfter, newStreamError("read", it.stream.url, resp.StatusCode, ErrStreamNotFound)
 
 	case http.StatusGone:
 		io.Copy(io.Discard, resp.Body)
-		return nil, newStreamError("read", it.stream.url, resp.StatusCode, ErrOffsetGone)
+		if events != nil {
+			events.OnGone()
+		}
+		return nil, resp.StatusCode, retryAfter, newStreamError("read", it.stream.url, resp.StatusCode, ErrOffsetGone)
 
 	default:
 		io.Copy(io.Discard, resp.Body)
-		return nil, newStreamError("read", it.stream.url, resp.StatusCode, errorFromStatus(resp.StatusCode))
+		return nil, resp.StatusCode, retryAfter, newStreamError("read", it.stream.url, resp.StatusCode, errorFromStatus(resp.StatusCode))
 	}
 }
 
@@ -0,0 +1,69 @@
+package durablestreams
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestNextRetriesMidBodyFailureOn200 is a regression test: a connection
+// drop while reading the body of a 200 response must be retried like
+// any other transport error, not treated as a permanent failure of the
+// (successful) HTTP status.
+func TestNextRetriesMidBodyFailureOn200(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			// Declare more bytes than we write, then close the
+			// connection early so io.ReadAll sees io.ErrUnexpectedEOF
+			// on a 200 response.
+			w.Header().Set("Content-Length", "100")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("short"))
+			hj, ok := w.(http.Hijacker)
+			if !ok {
+				t.Fatal("ResponseWriter does not support hijacking")
+			}
+			conn, _, err := hj.Hijack()
+			if err != nil {
+				t.Fatalf("hijack: %v", err)
+			}
+			conn.Close()
+			return
+		}
+
+		w.Header().Set(headerStreamOffset, "o")
+		w.Header().Set(headerStreamUpToDate, "true")
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	var retried int
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = 0
+	policy.OnRetry = func(attempt int, err error, statusCode int, sleep time.Duration) {
+		retried++
+		if statusCode != 0 {
+			t.Errorf("OnRetry statusCode = %d, want 0 for a mid-body transport failure", statusCode)
+		}
+	}
+
+	client := NewClient(WithRetryPolicy(policy))
+	stream := client.Stream(srv.URL)
+	it := stream.NewIterator(context.Background(), "", LiveModeNone)
+	defer it.Close()
+
+	chunk, err := it.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if string(chunk.Data) != "ok" {
+		t.Fatalf("got %q, want %q", chunk.Data, "ok")
+	}
+	if retried == 0 {
+		t.Fatal("expected at least one retry for the mid-body failure")
+	}
+}
@@ -0,0 +1,61 @@
+package durablestreams
+
+import "net/http"
+
+// clientConfig accumulates ClientOption values during NewClient.
+type clientConfig struct {
+	httpClient     *http.Client
+	baseURL        string
+	retryPolicy    *RetryPolicy
+	http2Options   *HTTP2Options
+	eventHandler   EventHandler
+	acceptEncoding string
+	autoDecompress *bool
+}
+
+// ClientOption configures a Client created by NewClient.
+type ClientOption func(*clientConfig)
+
+// WithHTTPClient overrides the *http.Client used for all requests.
+// Use this to share connection pools or add custom transports.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.httpClient = httpClient
+	}
+}
+
+// WithBaseURL sets a base URL that relative stream paths are resolved
+// against in Client.Stream.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.baseURL = baseURL
+	}
+}
+
+// WithRetryPolicy overrides the default RetryPolicy used by
+// ChunkIterator.Next to resume after transient read failures.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.retryPolicy = &policy
+	}
+}
+
+// WithAcceptEncoding overrides the Accept-Encoding header ChunkIterator
+// sends on read requests. The default is "gzip, zstd".
+func WithAcceptEncoding(encoding string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.acceptEncoding = encoding
+	}
+}
+
+// WithAutoDecompress controls whether ChunkIterator transparently
+// decompresses a gzip/zstd-encoded response body before returning it as
+// Chunk.Data. It defaults to true. Pass false when callers want the
+// compressed bytes as-is, e.g. to pass a chunk through to a downstream
+// consumer unchanged; Accept-Encoding is still sent in this case, only
+// the local decode step is skipped.
+func WithAutoDecompress(enabled bool) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.autoDecompress = &enabled
+	}
+}
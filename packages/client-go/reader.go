@@ -0,0 +1,97 @@
+package durablestreams
+
+import (
+	"errors"
+	"io"
+)
+
+// ChunkReader adapts a ChunkIterator to io.Reader, concatenating
+// Chunk.Data across Next calls. Create one with ChunkIterator.Reader.
+type ChunkReader struct {
+	it  *ChunkIterator
+	buf []byte
+}
+
+// Reader returns an io.ReadCloser view of it that concatenates
+// Chunk.Data bytes across calls. In live mode, reads block until new
+// data arrives; Read returns io.EOF once the iterator reaches Done.
+// 204/304 chunks carry no data and are consumed internally rather than
+// surfaced as zero-length reads.
+//
+// Close on the returned ChunkReader closes the underlying iterator.
+func (it *ChunkIterator) Reader() *ChunkReader {
+	return &ChunkReader{it: it}
+}
+
+// Offset returns the iterator's current position, for checkpointing
+// alongside writes made from this reader.
+func (r *ChunkReader) Offset() Offset {
+	return r.it.Offset
+}
+
+// Cursor returns the iterator's current CDN collapsing cursor.
+func (r *ChunkReader) Cursor() string {
+	return r.it.Cursor
+}
+
+// Read implements io.Reader.
+func (r *ChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.it.Next()
+		if errors.Is(err, Done) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Close closes the underlying ChunkIterator. Implements io.Closer.
+func (r *ChunkReader) Close() error {
+	return r.it.Close()
+}
+
+// WriteTo implements io.WriterTo, writing each chunk directly to w as
+// it arrives instead of buffering through Read, for a zero-extra-copy
+// io.Copy fast path.
+func (r *ChunkReader) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+
+	if len(r.buf) > 0 {
+		n, err := w.Write(r.buf)
+		total += int64(n)
+		r.buf = r.buf[n:]
+		if err != nil {
+			return total, err
+		}
+	}
+
+	for {
+		chunk, err := r.it.Next()
+		if errors.Is(err, Done) {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+		if len(chunk.Data) == 0 {
+			continue
+		}
+		n, err := w.Write(chunk.Data)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// Ensure ChunkReader implements io.ReadCloser and io.WriterTo.
+var (
+	_ io.ReadCloser = (*ChunkReader)(nil)
+	_ io.WriterTo   = (*ChunkReader)(nil)
+)
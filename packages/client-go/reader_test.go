@@ -0,0 +1,88 @@
+package durablestreams
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestServer serves the given bodies in order on successive GETs,
+// marking the stream up to date on the last one.
+func newTestServer(t *testing.T, bodies []string) *httptest.Server {
+	t.Helper()
+	var n int
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n >= len(bodies) {
+			w.Header().Set(headerStreamUpToDate, "true")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		body := bodies[n]
+		last := n == len(bodies)-1
+		n++
+
+		w.Header().Set(headerStreamOffset, "o")
+		if last {
+			w.Header().Set(headerStreamUpToDate, "true")
+		}
+		w.Write([]byte(body))
+	}))
+}
+
+func TestChunkReaderReadConcatenatesChunks(t *testing.T) {
+	srv := newTestServer(t, []string{"hello ", "world"})
+	defer srv.Close()
+
+	client := NewClient()
+	stream := client.Stream(srv.URL)
+	it := stream.NewIterator(context.Background(), "", LiveModeNone)
+	defer it.Close()
+
+	data, err := io.ReadAll(it.Reader())
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(data) != "hello world" {
+		t.Fatalf("got %q, want %q", data, "hello world")
+	}
+}
+
+func TestChunkReaderWriteToFastPath(t *testing.T) {
+	srv := newTestServer(t, []string{"abc", "def"})
+	defer srv.Close()
+
+	client := NewClient()
+	stream := client.Stream(srv.URL)
+	it := stream.NewIterator(context.Background(), "", LiveModeNone)
+	defer it.Close()
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, it.Reader())
+	if err != nil {
+		t.Fatalf("io.Copy: %v", err)
+	}
+	if n != 6 || buf.String() != "abcdef" {
+		t.Fatalf("got (%d, %q), want (6, %q)", n, buf.String(), "abcdef")
+	}
+}
+
+func TestChunkReaderOffsetAccessor(t *testing.T) {
+	srv := newTestServer(t, []string{"x"})
+	defer srv.Close()
+
+	client := NewClient()
+	stream := client.Stream(srv.URL)
+	it := stream.NewIterator(context.Background(), "", LiveModeNone)
+	defer it.Close()
+
+	r := it.Reader()
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if r.Offset() != "o" {
+		t.Fatalf("Offset() = %q, want %q", r.Offset(), "o")
+	}
+}
@@ -0,0 +1,140 @@
+package durablestreams
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// RetryPolicy controls how ChunkIterator.Next resumes idempotent GET
+// reads after a transient failure (connection errors, 5xx, 429/503).
+// The zero value is not directly usable; construct one with
+// DefaultRetryPolicy and override fields as needed.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for a single Next
+	// call, including the first. A value <= 1 disables retries.
+	MaxAttempts int
+
+	// BaseDelay is the starting backoff delay, doubled on each
+	// subsequent attempt (capped at MaxDelay) and randomized by up to
+	// +/-50% jitter.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the computed backoff delay, before Retry-After is
+	// taken into account.
+	MaxDelay time.Duration
+
+	// OnRetry, if set, is called after each failed attempt that will be
+	// retried, before the corresponding sleep. It is not called for the
+	// final failed attempt or for errors that are not retried. statusCode is 0 for transport-level
+	// errors (connection reset, EOF, etc).
+	OnRetry func(attempt int, err error, statusCode int, sleep time.Duration)
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used when a Client is
+// created without WithRetryPolicy: up to 5 attempts, starting at 200ms
+// and capped at 30s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// shouldRetry reports whether a read that failed with err (transport
+// error, statusCode == 0) or statusCode (HTTP error) is safe to retry.
+func (p RetryPolicy) shouldRetry(err error, statusCode int) bool {
+	if statusCode != 0 {
+		switch statusCode {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusTooEarly:
+			return true
+		case http.StatusNotFound, http.StatusGone:
+			return false
+		}
+		if statusCode >= 500 {
+			return true
+		}
+		if statusCode >= 400 {
+			return false
+		}
+		return false
+	}
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	if isResetOrRefusedStreamError(err) {
+		return true
+	}
+	return false
+}
+
+// backoff computes the delay before the given attempt (1-indexed),
+// honoring a server-provided Retry-After when present.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	delay := p.BaseDelay << uint(attempt-1)
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		// A zero (or negative, from overflow on a very large attempt)
+		// delay has no meaningful jitter range; rand.Int63n panics on a
+		// non-positive argument, so just retry immediately.
+		return 0
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay))) - delay/2
+	delay += jitter
+	if delay < 0 {
+		delay = p.BaseDelay
+	}
+	return delay
+}
+
+// isResetOrRefusedStreamError reports whether err looks like a
+// connection reset or an HTTP/2 refused-stream/GOAWAY error, both of
+// which are safe to retry since the request was never processed by the
+// origin.
+func isResetOrRefusedStreamError(err error) bool {
+	var goAway http2.GoAwayError
+	if errors.As(err, &goAway) {
+		return true
+	}
+	var streamErr http2.StreamError
+	if errors.As(err, &streamErr) && streamErr.Code == http2.ErrCodeRefusedStream {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		msg := netErr.Error()
+		if strings.Contains(msg, "connection reset") || strings.Contains(msg, "broken pipe") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryAfter parses an RFC 7231 Retry-After header (delay-seconds
+// form only; durable-streams servers never send an HTTP-date here).
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	secs, err := strconv.Atoi(v)
+	if err != nil || secs < 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
@@ -0,0 +1,78 @@
+package durablestreams
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	p := DefaultRetryPolicy()
+
+	cases := []struct {
+		name       string
+		err        error
+		statusCode int
+		want       bool
+	}{
+		{"503", nil, http.StatusServiceUnavailable, true},
+		{"502", nil, http.StatusBadGateway, true},
+		{"429", nil, http.StatusTooManyRequests, true},
+		{"408", nil, http.StatusRequestTimeout, true},
+		{"404", nil, http.StatusNotFound, false},
+		{"410", nil, http.StatusGone, false},
+		{"400", nil, http.StatusBadRequest, false},
+		{"eof", io.EOF, 0, true},
+		{"unexpected eof", io.ErrUnexpectedEOF, 0, true},
+		{"goaway", http2.GoAwayError{ErrCode: http2.ErrCodeNo}, 0, true},
+		{"refused stream", http2.StreamError{Code: http2.ErrCodeRefusedStream}, 0, true},
+		{"other stream error", http2.StreamError{Code: http2.ErrCodeProtocol}, 0, false},
+		{"other transport error", errors.New("boom"), 0, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := p.shouldRetry(tc.err, tc.statusCode); got != tc.want {
+				t.Errorf("shouldRetry(%v, %d) = %v, want %v", tc.err, tc.statusCode, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoffHonorsRetryAfter(t *testing.T) {
+	p := DefaultRetryPolicy()
+	if got := p.backoff(1, 5*time.Second); got != 5*time.Second {
+		t.Fatalf("backoff with Retry-After = %v, want 5s", got)
+	}
+}
+
+func TestRetryPolicyBackoffZeroBaseDelayDoesNotPanic(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, BaseDelay: 0, MaxDelay: 0}
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("backoff panicked with zero BaseDelay: %v", r)
+		}
+	}()
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		if got := p.backoff(attempt, 0); got != 0 {
+			t.Fatalf("backoff(%d, 0) = %v, want 0", attempt, got)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffCapsAtMaxDelay(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 2 * time.Second}
+
+	// Uncapped, BaseDelay << 19 would be about six days; the cap brings
+	// it to MaxDelay, and jitter then moves it by up to +/-50%.
+	got := p.backoff(20, 0)
+	if got < 0 || got > 2*p.MaxDelay {
+		t.Fatalf("backoff(20, 0) = %v, want within [0, %v]", got, 2*p.MaxDelay)
+	}
+}
@@ -0,0 +1,64 @@
+package durablestreams
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Offset identifies a position within a stream.
+// The zero value ("") means "start of stream".
+type Offset string
+
+// LiveMode controls whether a ChunkIterator blocks waiting for new data
+// once it has caught up to the stream head.
+type LiveMode int
+
+const (
+	// LiveModeNone stops iteration once the stream head is reached.
+	LiveModeNone LiveMode = iota
+
+	// LiveModeLongPoll keeps the iterator open, long-polling the server
+	// for new data after catching up.
+	LiveModeLongPoll
+)
+
+// Stream is a handle to a single durable stream.
+// Create one with Client.Stream.
+type Stream struct {
+	url    string
+	client *Client
+}
+
+// NewIterator creates a ChunkIterator starting at offset, reading until
+// the stream head (live == LiveModeNone) or continuing to long-poll for
+// new data (live == LiveModeLongPoll).
+func (s *Stream) NewIterator(ctx context.Context, offset Offset, live LiveMode) *ChunkIterator {
+	ctx, cancel := context.WithCancel(ctx)
+	return &ChunkIterator{
+		stream: s,
+		ctx:    ctx,
+		cancel: cancel,
+		offset: offset,
+		live:   live,
+	}
+}
+
+// buildReadURL constructs the GET URL for reading the stream starting at
+// offset, carrying the CDN collapsing cursor when present.
+func (s *Stream) buildReadURL(offset Offset, live LiveMode, cursor string) string {
+	q := url.Values{}
+	if offset != "" {
+		q.Set("offset", string(offset))
+	}
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+	if live == LiveModeLongPoll {
+		q.Set("live", "true")
+	}
+	if len(q) == 0 {
+		return s.url
+	}
+	return fmt.Sprintf("%s?%s", s.url, q.Encode())
+}